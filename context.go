@@ -0,0 +1,95 @@
+// context.go
+package eventbus
+
+import (
+	"context"
+	"log"
+	"slices"
+)
+
+// SubscribeCtx registers a new subscriber for topic, like Subscribe, but
+// unsubscribes and closes the returned channel automatically when ctx is
+// done. This avoids the common leak where a caller forgets to call
+// Unsubscribe on shutdown.
+func (bus *EventBus) SubscribeCtx(ctx context.Context, topic string) <-chan any {
+	ch := bus.Subscribe(topic)
+	bus.watchUnsubscribe(ctx, topic, ch)
+	return ch
+}
+
+// SubscribeWithArgsCtx registers a query-filtered subscriber for topic, like
+// SubscribeWithArgs, but removes and closes the returned channel
+// automatically when ctx is done.
+func (bus *EventBus) SubscribeWithArgsCtx(ctx context.Context, topic string, args SubscribeArgs) <-chan any {
+	ch := bus.SubscribeWithArgs(topic, args)
+	bus.wg.Add(1)
+	go func() {
+		defer bus.wg.Done()
+		select {
+		case <-ctx.Done():
+			bus.removeQuerySub(topic, ch)
+		case <-bus.quitCh:
+		}
+	}()
+	return ch
+}
+
+// watchUnsubscribe spawns the watcher goroutine backing SubscribeCtx: it
+// unsubscribes ch from topic once ctx is done, or exits without acting once
+// the bus itself is closed (Close already closes every subscriber channel).
+func (bus *EventBus) watchUnsubscribe(ctx context.Context, topic string, ch <-chan any) {
+	bus.wg.Add(1)
+	go func() {
+		defer bus.wg.Done()
+		select {
+		case <-ctx.Done():
+			bus.Unsubscribe(topic, ch)
+		case <-bus.quitCh:
+		}
+	}()
+}
+
+// NewSubscriptionCtx behaves like NewSubscription, but closes the returned
+// Subscription automatically when ctx is done.
+func NewSubscriptionCtx[T any](ctx context.Context, bus Bus) (*Subscription[T], error) {
+	sub, err := NewSubscription[T](bus)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = sub.Close()
+	}()
+	return sub, nil
+}
+
+// PublishCtx sends data to all subscribers of topic, like Publish, but each
+// subscriber's send also selects on ctx.Done(), letting a caller bound
+// publish latency without closing the whole bus.
+func (bus *EventBus) PublishCtx(ctx context.Context, topic string, data any) {
+	bus.lock.RLock()
+	if bus.closed {
+		bus.lock.RUnlock()
+		return
+	}
+	entries := slices.Clone(bus.subscribers[topic])
+	bus.lock.RUnlock()
+
+	for _, e := range entries {
+		bus.wg.Add(1)
+		go func(e *subscriberEntry) {
+			defer bus.wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("eventbus: recovered from subscriber panic: %v", r)
+				}
+			}()
+			select {
+			case e.ch <- data:
+				e.recordDelivered()
+			case <-bus.quitCh:
+			case <-ctx.Done():
+			}
+		}(e)
+	}
+}
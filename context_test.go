@@ -0,0 +1,84 @@
+// context_test.go
+package eventbus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eventbus "github.com/dozyio/go-event-bus"
+)
+
+func TestSubscribeCtxCancel(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := bus.SubscribeCtx(ctx, "topic")
+
+	if c := bus.SubscriberCount("topic"); c != 1 {
+		t.Fatalf("got SubscriberCount %d; want 1", c)
+	}
+
+	cancel()
+
+	if _, ok := receive(t, ch, 200*time.Millisecond); ok {
+		t.Fatal("expected channel to be closed after context cancellation")
+	}
+	if c := bus.SubscriberCount("topic"); c != 0 {
+		t.Fatalf("got SubscriberCount %d after cancel; want 0", c)
+	}
+}
+
+func TestSubscribeWithArgsCtxCancel(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := bus.SubscribeWithArgsCtx(ctx, "topic", eventbus.SubscribeArgs{ClientID: "c1"})
+
+	cancel()
+
+	if _, ok := receive(t, ch, 200*time.Millisecond); ok {
+		t.Fatal("expected channel to be closed after context cancellation")
+	}
+}
+
+func TestPublishCtxTimeout(t *testing.T) {
+	bus := eventbus.NewEventBus()
+	bus.Subscribe("topic") // never drained
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		bus.PublishCtx(ctx, "topic", "data")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("PublishCtx did not return after ctx timeout")
+	}
+}
+
+func TestNewSubscriptionCtxCancel(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := eventbus.NewSubscriptionCtx[tradeEvent](ctx, bus)
+	if err != nil {
+		t.Fatalf("NewSubscriptionCtx: %v", err)
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-sub.Out():
+		if ok {
+			t.Fatal("expected subscription channel to be closed after context cancellation")
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("timed out waiting for subscription channel to close")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"slices"
 	"sync"
 )
@@ -12,11 +13,18 @@ import (
 // EventBus represents a basic event bus with graceful shutdown,
 // panic-isolation, and introspection capabilities.
 type EventBus struct {
-	subscribers map[string][]chan any
+	subscribers map[string][]*subscriberEntry
+	topicLimits map[string]int
+	typed       map[reflect.Type]*typedNode
+	querySubs   map[string][]*querySubscription
 	lock        sync.RWMutex
 	wg          sync.WaitGroup
 	closed      bool
 	quitCh      chan struct{}
+
+	observerLock   sync.Mutex
+	observers      []observerEntry
+	nextObserverID int
 }
 
 // ErrBusClosed is returned by PublishSync if the bus has been closed.
@@ -25,24 +33,53 @@ var ErrBusClosed = errors.New("eventbus: bus closed")
 // NewEventBus initializes and returns a new EventBus.
 func NewEventBus() *EventBus {
 	return &EventBus{
-		subscribers: make(map[string][]chan any),
+		subscribers: make(map[string][]*subscriberEntry),
+		topicLimits: make(map[string]int),
+		typed:       make(map[reflect.Type]*typedNode),
+		querySubs:   make(map[string][]*querySubscription),
 		quitCh:      make(chan struct{}),
 	}
 }
 
-// Subscribe registers a new subscriber for a given topic.
-// It returns a read-only channel that the subscriber can listen on.
+// Subscribe registers a new subscriber for a given topic, with an unbuffered
+// channel and the default Block overflow policy. It returns a read-only
+// channel that the subscriber can listen on. If topic already has a
+// SubscriberLimit configured (see SubscribeWithOptions) and it has been
+// reached, Subscribe returns an already-closed channel.
 func (bus *EventBus) Subscribe(topic string) <-chan any {
-	ch := make(chan any)
 	bus.lock.Lock()
 	defer bus.lock.Unlock()
 
 	if bus.closed {
+		ch := make(chan any)
+		close(ch)
+		return ch
+	}
+	entry, err := bus.newSubscriberLocked(topic, SubscribeOptions{})
+	if err != nil {
+		ch := make(chan any)
 		close(ch)
 		return ch
 	}
-	bus.subscribers[topic] = append(bus.subscribers[topic], ch)
-	return ch
+	return entry.ch
+}
+
+// newSubscriberLocked creates and registers a subscriberEntry for topic. The
+// caller must hold bus.lock for writing.
+func (bus *EventBus) newSubscriberLocked(topic string, opts SubscribeOptions) (*subscriberEntry, error) {
+	if opts.SubscriberLimit > 0 {
+		bus.topicLimits[topic] = opts.SubscriberLimit
+	}
+	if limit := bus.topicLimits[topic]; limit > 0 && len(bus.subscribers[topic]) >= limit {
+		return nil, ErrTooManySubscribers
+	}
+
+	entry := &subscriberEntry{
+		ch:   make(chan any, opts.BufferSize),
+		opts: opts,
+	}
+	bus.subscribers[topic] = append(bus.subscribers[topic], entry)
+	return entry, nil
 }
 
 // Unsubscribe removes a subscriber channel from the topic.
@@ -51,62 +88,84 @@ func (bus *EventBus) Unsubscribe(topic string, subscriber <-chan any) {
 	bus.lock.Lock()
 	defer bus.lock.Unlock()
 
-	subs := bus.subscribers[topic]
-	for i, sub := range subs {
-		if sub == subscriber {
-			bus.subscribers[topic] = slices.Delete(subs, i, i+1)
-			close(sub)
+	entries := bus.subscribers[topic]
+	for i, e := range entries {
+		if e.ch == subscriber {
+			bus.subscribers[topic] = slices.Delete(entries, i, i+1)
+			close(e.ch)
 			break
 		}
 	}
 }
 
-// Publish sends the data to all subscribers of the given topic.
-// Each subscriber receives the event in its own goroutine, with panic isolation.
-// If the bus has been closed, Publish is a no-op.
-// During shutdown, any blocked sends are unblocked via quitCh rather than a channel close.
+// Publish runs every observer registered via Observe, in publish order, then
+// sends the data to all subscribers of the given topic and applies each
+// subscriber's OverflowPolicy. Subscribers using the default Block policy
+// receive the event in their own goroutine, with panic isolation, as do all
+// unbuffered subscribers; buffered subscribers with another policy are
+// served inline via a non-blocking send. SubscribeWithArgs subscribers on
+// topic receive it as an event with no tags. If the bus has been
+// closed, Publish is a no-op. During shutdown, any blocked sends are
+// unblocked via quitCh rather than a channel close.
 func (bus *EventBus) Publish(topic string, data any) {
 	bus.lock.RLock()
 	if bus.closed {
 		bus.lock.RUnlock()
 		return
 	}
-	subs := slices.Clone(bus.subscribers[topic])
+	entries := slices.Clone(bus.subscribers[topic])
 	bus.lock.RUnlock()
 
-	for _, sub := range subs {
-		bus.wg.Add(1)
-		go func(ch chan any) {
-			defer bus.wg.Done()
-			defer func() {
-				if r := recover(); r != nil {
-					log.Printf("eventbus: recovered from subscriber panic: %v", r)
+	bus.notifyObservers(topic, data)
+
+	for _, e := range entries {
+		policy := e.opts.OverflowPolicy
+		if e.opts.BufferSize == 0 {
+			policy = Block // an unbuffered channel always blocks
+		}
+		switch policy {
+		case DropOldest, DropNewest, CloseSlow:
+			bus.deliverNonBlocking(topic, e, data)
+		default: // Block
+			bus.wg.Add(1)
+			go func(e *subscriberEntry) {
+				defer bus.wg.Done()
+				defer func() {
+					if r := recover(); r != nil {
+						log.Printf("eventbus: recovered from subscriber panic: %v", r)
+					}
+				}()
+				select {
+				case e.ch <- data:
+					e.recordDelivered()
+				case <-bus.quitCh:
 				}
-			}()
-			select {
-			case ch <- data:
-			case <-bus.quitCh:
-			}
-		}(sub)
+			}(e)
+		}
 	}
+
+	bus.PublishWithTags(topic, data, nil)
 }
 
-// PublishSync sends the data to all subscribers of the given topic
-// synchronously, one after the other, waiting for each send to complete.
-// It recovers from panics (e.g. if someone manually closed a subscriber-ch).
-// If the bus is closed, it returns ErrBusClosed immediately.
-// If any subscriber panicked, it returns an aggregated error.
+// PublishSync runs every observer registered via Observe, in publish order,
+// then sends the data to all subscribers of the given topic synchronously,
+// one after the other, waiting for each send to complete. It recovers from
+// panics (e.g. if someone manually closed a subscriber-ch). If the bus is
+// closed, it returns ErrBusClosed immediately. If any subscriber panicked,
+// it returns an aggregated error.
 func (bus *EventBus) PublishSync(topic string, data any) error {
 	bus.lock.RLock()
 	if bus.closed {
 		bus.lock.RUnlock()
 		return ErrBusClosed
 	}
-	subs := slices.Clone(bus.subscribers[topic])
+	entries := slices.Clone(bus.subscribers[topic])
 	bus.lock.RUnlock()
 
+	bus.notifyObservers(topic, data)
+
 	var errs []error
-	for i, sub := range subs {
+	for i, e := range entries {
 		func(ch chan any) {
 			defer func() {
 				if r := recover(); r != nil {
@@ -114,7 +173,7 @@ func (bus *EventBus) PublishSync(topic string, data any) error {
 				}
 			}()
 			ch <- data
-		}(sub)
+		}(e.ch)
 	}
 	if len(errs) > 0 {
 		return fmt.Errorf("PublishSync: %d error(s); first: %v", len(errs), errs[0])
@@ -141,12 +200,24 @@ func (bus *EventBus) Close() {
 
 	// now safe to close subscriber channels (no sends in flight)
 	bus.lock.Lock()
-	for topic, subs := range bus.subscribers {
-		for _, sub := range subs {
-			close(sub)
+	for topic, entries := range bus.subscribers {
+		for _, e := range entries {
+			close(e.ch)
 		}
 		delete(bus.subscribers, topic)
 	}
+	for t, node := range bus.typed {
+		for _, sink := range node.sinks {
+			sink.Close()
+		}
+		delete(bus.typed, t)
+	}
+	for topic, subs := range bus.querySubs {
+		for _, s := range subs {
+			close(s.ch)
+		}
+		delete(bus.querySubs, topic)
+	}
 	bus.lock.Unlock()
 }
 
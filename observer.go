@@ -0,0 +1,73 @@
+// observer.go
+package eventbus
+
+import (
+	"log"
+	"slices"
+	"sync"
+)
+
+// ObserverErrorHandler is invoked when a function registered via Observe
+// panics. It defaults to logging via the standard logger; override it to
+// route observer failures to a different sink (e.g. metrics or alerting).
+var ObserverErrorHandler = func(topic string, data any, r any) {
+	log.Printf("eventbus: observer panic on topic %q: %v", topic, r)
+}
+
+// observerEntry is the bookkeeping behind a func registered via Observe.
+type observerEntry struct {
+	id int
+	fn func(topic string, data any)
+}
+
+// Observe registers fn to run synchronously, in registration order, before
+// subscriber fan-out on every Publish and PublishSync call. Observers run
+// under a dedicated mutex, so their ordering is total across the bus, making
+// Observe suitable for indexing, persistence, or audit logging that must see
+// every event in publish order without racing subscriber goroutines. Unlike
+// subscribers, a panicking observer is recovered and reported via
+// ObserverErrorHandler rather than breaking the fan-out path or being
+// aggregated into PublishSync's returned error.
+//
+// The returned remove func deregisters fn; calling it more than once is a
+// no-op.
+func (bus *EventBus) Observe(fn func(topic string, data any)) (remove func()) {
+	bus.observerLock.Lock()
+	id := bus.nextObserverID
+	bus.nextObserverID++
+	bus.observers = append(bus.observers, observerEntry{id: id, fn: fn})
+	bus.observerLock.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			bus.observerLock.Lock()
+			defer bus.observerLock.Unlock()
+			for i, o := range bus.observers {
+				if o.id == id {
+					bus.observers = slices.Delete(bus.observers, i, i+1)
+					break
+				}
+			}
+		})
+	}
+}
+
+// notifyObservers runs every registered observer against (topic, data), in
+// registration order, recovering and reporting any panic via
+// ObserverErrorHandler.
+func (bus *EventBus) notifyObservers(topic string, data any) {
+	bus.observerLock.Lock()
+	defer bus.observerLock.Unlock()
+
+	for _, o := range bus.observers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					ObserverErrorHandler(topic, data, r)
+				}
+			}()
+			o.fn(topic, data)
+		}()
+	}
+}
@@ -0,0 +1,109 @@
+// observer_test.go
+package eventbus_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	eventbus "github.com/dozyio/go-event-bus"
+)
+
+func TestObserveRunsBeforeSubscribers(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	var mu sync.Mutex
+	var order []string
+
+	remove := bus.Observe(func(topic string, data any) {
+		mu.Lock()
+		order = append(order, "observer:"+data.(string))
+		mu.Unlock()
+	})
+	defer remove()
+
+	ch := bus.Subscribe("topic")
+	bus.Publish("topic", "hello")
+
+	if _, ok := receive(t, ch, 200*time.Millisecond); !ok {
+		t.Fatal("expected channel to be open")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 1 || order[0] != "observer:hello" {
+		t.Fatalf("got %v; want [observer:hello]", order)
+	}
+}
+
+func TestObserveOrderIsTotal(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	var mu sync.Mutex
+	var order []int
+
+	bus.Observe(func(topic string, data any) {
+		mu.Lock()
+		order = append(order, data.(int))
+		mu.Unlock()
+	})
+
+	for i := 0; i < 10; i++ {
+		bus.PublishSync("topic", i)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, v := range order {
+		if v != i {
+			t.Fatalf("got order %v; want events delivered in publish order", order)
+		}
+	}
+}
+
+func TestRemoveObserver(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	calls := 0
+	remove := bus.Observe(func(topic string, data any) {
+		calls++
+	})
+
+	bus.PublishSync("topic", 1)
+	remove()
+	remove() // idempotent
+	bus.PublishSync("topic", 2)
+
+	if calls != 1 {
+		t.Fatalf("got %d observer calls; want 1", calls)
+	}
+}
+
+func TestObserverPanicIsRecoveredAndReported(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	orig := eventbus.ObserverErrorHandler
+	defer func() { eventbus.ObserverErrorHandler = orig }()
+
+	reported := make(chan any, 1)
+	eventbus.ObserverErrorHandler = func(topic string, data any, r any) {
+		reported <- r
+	}
+
+	bus.Observe(func(topic string, data any) {
+		panic("boom")
+	})
+
+	if err := bus.PublishSync("topic", "x"); err != nil {
+		t.Fatalf("observer panic should not surface via PublishSync's return: %v", err)
+	}
+
+	select {
+	case r := <-reported:
+		if r != "boom" {
+			t.Fatalf("got %v; want %q", r, "boom")
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("expected ObserverErrorHandler to be called")
+	}
+}
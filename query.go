@@ -0,0 +1,354 @@
+// query.go
+package eventbus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// queryOp is a comparison operator recognized by the query parser.
+type queryOp int
+
+const (
+	opEq queryOp = iota
+	opNe
+	opLt
+	opLe
+	opGt
+	opGe
+	opContains
+	opExists
+)
+
+// queryNode is one node of a compiled Query's predicate tree.
+type queryNode interface {
+	match(tags map[string]string) bool
+}
+
+type andNode struct{ left, right queryNode }
+
+func (n *andNode) match(tags map[string]string) bool {
+	return n.left.match(tags) && n.right.match(tags)
+}
+
+type orNode struct{ left, right queryNode }
+
+func (n *orNode) match(tags map[string]string) bool {
+	return n.left.match(tags) || n.right.match(tags)
+}
+
+type cmpNode struct {
+	key   string
+	op    queryOp
+	value string
+}
+
+func (n *cmpNode) match(tags map[string]string) bool {
+	if n.op == opExists {
+		_, ok := tags[n.key]
+		return ok
+	}
+
+	actual, ok := tags[n.key]
+	if !ok {
+		return false
+	}
+
+	switch n.op {
+	case opEq:
+		return actual == n.value
+	case opNe:
+		return actual != n.value
+	case opContains:
+		return strings.Contains(actual, n.value)
+	case opLt, opLe, opGt, opGe:
+		af, aerr := strconv.ParseFloat(actual, 64)
+		vf, verr := strconv.ParseFloat(n.value, 64)
+		if aerr != nil || verr != nil {
+			return false
+		}
+		switch n.op {
+		case opLt:
+			return af < vf
+		case opLe:
+			return af <= vf
+		case opGt:
+			return af > vf
+		case opGe:
+			return af >= vf
+		}
+	}
+	return false
+}
+
+// Query is a compiled predicate evaluated against an event's tags, as
+// published via PublishWithTags. Build one with ParseQuery.
+type Query struct {
+	src  string
+	root queryNode
+}
+
+// String returns the original query text.
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.src
+}
+
+// Match reports whether tags satisfies the query. A nil Query matches every
+// event.
+func (q *Query) Match(tags map[string]string) bool {
+	if q == nil || q.root == nil {
+		return true
+	}
+	return q.root.match(tags)
+}
+
+// ParseQuery compiles a small predicate DSL over an event's tag map, e.g.
+// `topic='trades' AND symbol='BTC' AND price>100`. Supported operators are
+// AND, OR, =, !=, <, <=, >, >=, CONTAINS and EXISTS(key); string literals
+// may be single- or double-quoted, numeric literals are bare.
+func ParseQuery(s string) (*Query, error) {
+	toks, err := lexQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{toks: toks}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.toks) {
+		return nil, fmt.Errorf("eventbus: unexpected token %q in query", p.toks[p.pos].text)
+	}
+	return &Query{src: s, root: root}, nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokAnd
+	tokOr
+	tokExists
+	tokContains
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type queryToken struct {
+	kind tokKind
+	text string
+}
+
+// lexQuery tokenizes a query string into identifiers, literals, operators
+// and keywords.
+func lexQuery(s string) ([]queryToken, error) {
+	var toks []queryToken
+	r := []rune(s)
+	i := 0
+	for i < len(r) {
+		c := r[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			toks = append(toks, queryToken{kind: tokLParen, text: "("})
+			i++
+		case c == ')':
+			toks = append(toks, queryToken{kind: tokRParen, text: ")"})
+			i++
+		case c == '\'' || c == '"':
+			quote := c
+			j := i + 1
+			for j < len(r) && r[j] != quote {
+				j++
+			}
+			if j >= len(r) {
+				return nil, fmt.Errorf("eventbus: unterminated string literal in query")
+			}
+			toks = append(toks, queryToken{kind: tokString, text: string(r[i+1 : j])})
+			i = j + 1
+		case c == '!' && i+1 < len(r) && r[i+1] == '=':
+			toks = append(toks, queryToken{kind: tokOp, text: "!="})
+			i += 2
+		case c == '<' && i+1 < len(r) && r[i+1] == '=':
+			toks = append(toks, queryToken{kind: tokOp, text: "<="})
+			i += 2
+		case c == '>' && i+1 < len(r) && r[i+1] == '=':
+			toks = append(toks, queryToken{kind: tokOp, text: ">="})
+			i += 2
+		case c == '=' || c == '<' || c == '>':
+			toks = append(toks, queryToken{kind: tokOp, text: string(c)})
+			i++
+		case isQueryIdentRune(c) || (c >= '0' && c <= '9') || (c == '-' && i+1 < len(r) && r[i+1] >= '0' && r[i+1] <= '9'):
+			j := i + 1
+			for j < len(r) && (isQueryIdentRune(r[j]) || (r[j] >= '0' && r[j] <= '9') || r[j] == '.') {
+				j++
+			}
+			word := string(r[i:j])
+			i = j
+			switch strings.ToUpper(word) {
+			case "AND":
+				toks = append(toks, queryToken{kind: tokAnd, text: word})
+			case "OR":
+				toks = append(toks, queryToken{kind: tokOr, text: word})
+			case "EXISTS":
+				toks = append(toks, queryToken{kind: tokExists, text: word})
+			case "CONTAINS":
+				toks = append(toks, queryToken{kind: tokContains, text: word})
+			default:
+				if isQueryNumber(word) {
+					toks = append(toks, queryToken{kind: tokNumber, text: word})
+				} else {
+					toks = append(toks, queryToken{kind: tokIdent, text: word})
+				}
+			}
+		default:
+			return nil, fmt.Errorf("eventbus: unexpected character %q in query", c)
+		}
+	}
+	return toks, nil
+}
+
+func isQueryIdentRune(c rune) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isQueryNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+// queryParser is a small recursive-descent parser over queryToken, for the
+// grammar:
+//
+//	expr   := term (OR term)*
+//	term   := factor (AND factor)*
+//	factor := '(' expr ')' | EXISTS '(' IDENT ')' | IDENT OP (STRING | NUMBER)
+type queryParser struct {
+	toks []queryToken
+	pos  int
+}
+
+func (p *queryParser) peek() queryToken {
+	if p.pos >= len(p.toks) {
+		return queryToken{kind: tokEOF}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *queryParser) parseExpr() (queryNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseTerm() (queryNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseFactor() (queryNode, error) {
+	switch t := p.peek(); t.kind {
+	case tokLParen:
+		p.next()
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, fmt.Errorf("eventbus: expected ')' in query")
+		}
+		p.next()
+		return n, nil
+	case tokExists:
+		p.next()
+		if p.peek().kind != tokLParen {
+			return nil, fmt.Errorf("eventbus: expected '(' after EXISTS")
+		}
+		p.next()
+		key := p.next()
+		if key.kind != tokIdent {
+			return nil, fmt.Errorf("eventbus: expected tag name in EXISTS(...)")
+		}
+		if p.peek().kind != tokRParen {
+			return nil, fmt.Errorf("eventbus: expected ')' after EXISTS(%s", key.text)
+		}
+		p.next()
+		return &cmpNode{key: key.text, op: opExists}, nil
+	case tokIdent:
+		p.next()
+		return p.parseComparison(t.text)
+	default:
+		return nil, fmt.Errorf("eventbus: unexpected token %q in query", t.text)
+	}
+}
+
+func (p *queryParser) parseComparison(key string) (queryNode, error) {
+	opTok := p.peek()
+	var op queryOp
+	switch {
+	case opTok.kind == tokContains:
+		op = opContains
+	case opTok.kind == tokOp:
+		switch opTok.text {
+		case "=":
+			op = opEq
+		case "!=":
+			op = opNe
+		case "<":
+			op = opLt
+		case "<=":
+			op = opLe
+		case ">":
+			op = opGt
+		case ">=":
+			op = opGe
+		default:
+			return nil, fmt.Errorf("eventbus: unknown operator %q in query", opTok.text)
+		}
+	default:
+		return nil, fmt.Errorf("eventbus: expected operator after %q in query", key)
+	}
+	p.next()
+
+	valTok := p.next()
+	if valTok.kind != tokString && valTok.kind != tokNumber {
+		return nil, fmt.Errorf("eventbus: expected value after %q %v", key, opTok.text)
+	}
+	return &cmpNode{key: key, op: op, value: valTok.text}, nil
+}
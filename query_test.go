@@ -0,0 +1,52 @@
+// query_test.go
+package eventbus_test
+
+import (
+	"testing"
+
+	eventbus "github.com/dozyio/go-event-bus"
+)
+
+func TestParseQueryMatch(t *testing.T) {
+	cases := []struct {
+		query string
+		tags  map[string]string
+		want  bool
+	}{
+		{`symbol='BTC'`, map[string]string{"symbol": "BTC"}, true},
+		{`symbol='BTC'`, map[string]string{"symbol": "ETH"}, false},
+		{`symbol!='BTC'`, map[string]string{"symbol": "ETH"}, true},
+		{`price>100`, map[string]string{"price": "200"}, true},
+		{`price>100`, map[string]string{"price": "50"}, false},
+		{`price>=200`, map[string]string{"price": "200"}, true},
+		{`price<=100 AND symbol='BTC'`, map[string]string{"price": "100", "symbol": "BTC"}, true},
+		{`symbol='BTC' OR symbol='ETH'`, map[string]string{"symbol": "ETH"}, true},
+		{`(symbol='BTC' OR symbol='ETH') AND price>100`, map[string]string{"symbol": "ETH", "price": "50"}, false},
+		{`EXISTS(region)`, map[string]string{"region": "us"}, true},
+		{`EXISTS(region)`, map[string]string{}, false},
+		{`name CONTAINS 'trad'`, map[string]string{"name": "trades"}, true},
+	}
+
+	for _, c := range cases {
+		q, err := eventbus.ParseQuery(c.query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q): %v", c.query, err)
+		}
+		if got := q.Match(c.tags); got != c.want {
+			t.Errorf("ParseQuery(%q).Match(%v) = %v; want %v", c.query, c.tags, got, c.want)
+		}
+	}
+}
+
+func TestParseQueryNilMatchesEverything(t *testing.T) {
+	var q *eventbus.Query
+	if !q.Match(map[string]string{"anything": "goes"}) {
+		t.Fatal("nil Query should match every event")
+	}
+}
+
+func TestParseQueryError(t *testing.T) {
+	if _, err := eventbus.ParseQuery("symbol="); err == nil {
+		t.Fatal("expected error for malformed query")
+	}
+}
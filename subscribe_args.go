@@ -0,0 +1,154 @@
+// subscribe_args.go
+package eventbus
+
+import (
+	"log"
+	"slices"
+)
+
+// SubscribeArgs configures a query-filtered subscription created via
+// SubscribeWithArgs.
+type SubscribeArgs struct {
+	// ClientID identifies the owner of the subscription, so every
+	// subscription belonging to it can later be removed in bulk via
+	// UnsubscribeAll.
+	ClientID string
+	// Query filters which events published via PublishWithTags are
+	// delivered to the returned channel. A nil Query matches every event
+	// published on the topic, including those without tags.
+	Query *Query
+	// Limit caps the number of events delivered before the subscription is
+	// automatically closed. Zero means unlimited.
+	Limit int
+}
+
+// querySubscription is the bookkeeping entry behind a channel returned by
+// SubscribeWithArgs.
+type querySubscription struct {
+	clientID  string
+	query     *Query
+	ch        chan any
+	limit     int
+	delivered int
+}
+
+// SubscribeWithArgs registers a query-filtered subscriber for topic. Unlike
+// Subscribe, delivery is gated by args.Query, which is matched against the
+// tags passed to PublishWithTags; events published via the plain Publish
+// (with no tags) only reach it if args.Query is nil or matches an empty tag
+// set.
+func (bus *EventBus) SubscribeWithArgs(topic string, args SubscribeArgs) <-chan any {
+	ch := make(chan any)
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+
+	if bus.closed {
+		close(ch)
+		return ch
+	}
+
+	sub := &querySubscription{
+		clientID: args.ClientID,
+		query:    args.Query,
+		ch:       ch,
+		limit:    args.Limit,
+	}
+	bus.querySubs[topic] = append(bus.querySubs[topic], sub)
+	return ch
+}
+
+// UnsubscribeAll removes and closes every subscription created via
+// SubscribeWithArgs with the given ClientID, across all topics. It is
+// intended for HTTP/WebSocket bridges that need to tear down a client's
+// subscriptions in one call on disconnect.
+func (bus *EventBus) UnsubscribeAll(clientID string) {
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+
+	for topic, subs := range bus.querySubs {
+		kept := subs[:0]
+		for _, s := range subs {
+			if s.clientID == clientID {
+				close(s.ch)
+				continue
+			}
+			kept = append(kept, s)
+		}
+		if len(kept) == 0 {
+			delete(bus.querySubs, topic)
+		} else {
+			bus.querySubs[topic] = kept
+		}
+	}
+}
+
+// removeQuerySub removes and closes the single querySubscription backed by
+// ch, if any.
+func (bus *EventBus) removeQuerySub(topic string, ch <-chan any) {
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+
+	subs := bus.querySubs[topic]
+	for i, s := range subs {
+		if s.ch == ch {
+			bus.querySubs[topic] = slices.Delete(subs, i, i+1)
+			close(s.ch)
+			break
+		}
+	}
+}
+
+// PublishWithTags sends data to every SubscribeWithArgs subscriber on topic
+// whose compiled Query matches tags, delivering to each in its own
+// goroutine with the same panic isolation and quitCh-guarded shutdown as
+// Publish. A subscription that reaches its Limit is closed after this
+// delivery.
+func (bus *EventBus) PublishWithTags(topic string, data any, tags map[string]string) {
+	bus.lock.Lock()
+	if bus.closed {
+		bus.lock.Unlock()
+		return
+	}
+
+	type delivery struct {
+		sub       *querySubscription
+		exhausted bool
+	}
+
+	subs := bus.querySubs[topic]
+	var deliveries []delivery
+	remaining := subs[:0:0]
+	for _, s := range subs {
+		if !s.query.Match(tags) {
+			remaining = append(remaining, s)
+			continue
+		}
+		s.delivered++
+		exhausted := s.limit > 0 && s.delivered >= s.limit
+		deliveries = append(deliveries, delivery{sub: s, exhausted: exhausted})
+		if !exhausted {
+			remaining = append(remaining, s)
+		}
+	}
+	bus.querySubs[topic] = remaining
+	bus.lock.Unlock()
+
+	for _, d := range deliveries {
+		bus.wg.Add(1)
+		go func(d delivery) {
+			defer bus.wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("eventbus: recovered from query subscriber panic: %v", r)
+				}
+			}()
+			select {
+			case d.sub.ch <- data:
+			case <-bus.quitCh:
+			}
+			if d.exhausted {
+				close(d.sub.ch)
+			}
+		}(d)
+	}
+}
@@ -0,0 +1,80 @@
+// subscribe_args_test.go
+package eventbus_test
+
+import (
+	"testing"
+	"time"
+
+	eventbus "github.com/dozyio/go-event-bus"
+)
+
+func TestSubscribeWithArgsMatchesQuery(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	q, err := eventbus.ParseQuery("symbol='BTC' AND price>100")
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+
+	ch := bus.SubscribeWithArgs("trades", eventbus.SubscribeArgs{ClientID: "c1", Query: q})
+
+	bus.PublishWithTags("trades", "eth-tick", map[string]string{"symbol": "ETH", "price": "200"})
+	bus.PublishWithTags("trades", "btc-tick", map[string]string{"symbol": "BTC", "price": "200"})
+
+	v, ok := receive(t, ch, 200*time.Millisecond)
+	if !ok {
+		t.Fatal("expected channel to be open")
+	}
+	if v.(string) != "btc-tick" {
+		t.Fatalf("got %v; want %q", v, "btc-tick")
+	}
+}
+
+func TestSubscribeWithArgsNilQueryMatchesAll(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	ch := bus.SubscribeWithArgs("trades", eventbus.SubscribeArgs{ClientID: "c1"})
+	bus.PublishWithTags("trades", "tick", nil)
+
+	if _, ok := receive(t, ch, 200*time.Millisecond); !ok {
+		t.Fatal("expected channel to be open")
+	}
+}
+
+func TestUnsubscribeAll(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	ch1 := bus.SubscribeWithArgs("a", eventbus.SubscribeArgs{ClientID: "c1"})
+	ch2 := bus.SubscribeWithArgs("b", eventbus.SubscribeArgs{ClientID: "c1"})
+	ch3 := bus.SubscribeWithArgs("a", eventbus.SubscribeArgs{ClientID: "c2"})
+
+	bus.UnsubscribeAll("c1")
+
+	if _, ok := <-ch1; ok {
+		t.Error("expected ch1 to be closed")
+	}
+	if _, ok := <-ch2; ok {
+		t.Error("expected ch2 to be closed")
+	}
+
+	bus.PublishWithTags("a", "still-alive", nil)
+	if v, ok := receive(t, ch3, 200*time.Millisecond); !ok || v.(string) != "still-alive" {
+		t.Fatalf("expected c2's subscription to survive, got %v (open=%v)", v, ok)
+	}
+}
+
+func TestSubscribeWithArgsLimit(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	ch := bus.SubscribeWithArgs("a", eventbus.SubscribeArgs{ClientID: "c1", Limit: 1})
+
+	bus.PublishWithTags("a", "first", nil)
+	if _, ok := receive(t, ch, 200*time.Millisecond); !ok {
+		t.Fatal("expected first event to be delivered")
+	}
+
+	// subscription should now be closed
+	if _, ok := receive(t, ch, 200*time.Millisecond); ok {
+		t.Fatal("expected channel to be closed after reaching Limit")
+	}
+}
@@ -0,0 +1,183 @@
+// subscribe_options.go
+package eventbus
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// defaultSlowTimeout is used by the CloseSlow policy when
+// SubscribeOptions.SlowTimeout is unset.
+const defaultSlowTimeout = 5 * time.Second
+
+// OverflowPolicy controls what Publish does when a subscriber's buffered
+// channel is full.
+type OverflowPolicy int
+
+const (
+	// Block waits for the subscriber to make room, the same behavior as an
+	// unbuffered channel. This is the default, used by Subscribe.
+	Block OverflowPolicy = iota
+	// DropOldest discards the oldest buffered event to make room for the
+	// new one.
+	DropOldest
+	// DropNewest discards the incoming event if the buffer is full.
+	DropNewest
+	// CloseSlow unsubscribes and closes the channel once it has stayed full
+	// for longer than SubscribeOptions.SlowTimeout.
+	CloseSlow
+)
+
+// SubscribeOptions configures a subscriber created via SubscribeWithOptions.
+type SubscribeOptions struct {
+	// BufferSize is the channel's buffer capacity. Zero means unbuffered,
+	// matching Subscribe.
+	BufferSize int
+	// OverflowPolicy controls what happens once the buffer is full. Only
+	// meaningful when BufferSize > 0; a full unbuffered channel always
+	// blocks like Block regardless of this setting.
+	OverflowPolicy OverflowPolicy
+	// SlowTimeout is how long a subscriber may stay full before CloseSlow
+	// drops it. Ignored by other policies. Zero uses a 5 second default.
+	SlowTimeout time.Duration
+	// SubscriberLimit caps the number of subscribers topic may have. Zero
+	// means unlimited. Once set for a topic (by any call that specifies a
+	// positive value), it applies to every later Subscribe and
+	// SubscribeWithOptions call on that topic.
+	SubscriberLimit int
+}
+
+// ErrTooManySubscribers is returned by SubscribeWithOptions (and by
+// Subscribe, via a pre-closed channel) when a topic's SubscriberLimit has
+// already been reached.
+var ErrTooManySubscribers = errors.New("eventbus: too many subscribers")
+
+// Stats reports delivery counters for one subscriber, as returned by
+// SubscriberStats.
+type Stats struct {
+	// Delivered counts events successfully sent to the subscriber.
+	Delivered int
+	// Dropped counts events discarded because of the subscriber's
+	// OverflowPolicy.
+	Dropped int
+	// HighWaterMark is the largest number of buffered, undelivered events
+	// observed for the subscriber.
+	HighWaterMark int
+}
+
+// subscriberEntry is the bookkeeping behind a channel returned by Subscribe
+// or SubscribeWithOptions.
+type subscriberEntry struct {
+	ch   chan any
+	opts SubscribeOptions
+
+	mu        sync.Mutex
+	stats     Stats
+	fullSince time.Time
+}
+
+func (e *subscriberEntry) recordDelivered() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stats.Delivered++
+	e.fullSince = time.Time{}
+	if n := len(e.ch); n > e.stats.HighWaterMark {
+		e.stats.HighWaterMark = n
+	}
+}
+
+func (e *subscriberEntry) recordDropped() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stats.Dropped++
+}
+
+// SubscribeWithOptions registers a new subscriber for topic with the given
+// buffering and overflow behavior. It returns ErrTooManySubscribers if
+// topic's SubscriberLimit (current or newly set by opts) has been reached.
+func (bus *EventBus) SubscribeWithOptions(topic string, opts SubscribeOptions) (<-chan any, error) {
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+
+	if bus.closed {
+		return nil, ErrBusClosed
+	}
+	entry, err := bus.newSubscriberLocked(topic, opts)
+	if err != nil {
+		return nil, err
+	}
+	return entry.ch, nil
+}
+
+// SubscriberStats returns a snapshot of delivery counters for every current
+// subscriber of topic, in the same order as they were subscribed.
+func (bus *EventBus) SubscriberStats(topic string) []Stats {
+	bus.lock.RLock()
+	entries := make([]*subscriberEntry, len(bus.subscribers[topic]))
+	copy(entries, bus.subscribers[topic])
+	bus.lock.RUnlock()
+
+	stats := make([]Stats, len(entries))
+	for i, e := range entries {
+		e.mu.Lock()
+		stats[i] = e.stats
+		e.mu.Unlock()
+	}
+	return stats
+}
+
+// deliverNonBlocking attempts a non-blocking send to e.ch, falling back to
+// e.opts.OverflowPolicy if the buffer is full. It runs synchronously on
+// Publish's calling goroutine, since none of DropOldest, DropNewest or
+// CloseSlow ever block.
+func (bus *EventBus) deliverNonBlocking(topic string, e *subscriberEntry, data any) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("eventbus: recovered from subscriber panic: %v", r)
+		}
+	}()
+
+	select {
+	case e.ch <- data:
+		e.recordDelivered()
+		return
+	default:
+	}
+
+	switch e.opts.OverflowPolicy {
+	case DropOldest:
+		select {
+		case <-e.ch:
+			e.recordDropped()
+		default:
+		}
+		select {
+		case e.ch <- data:
+			e.recordDelivered()
+		default:
+		}
+	case DropNewest:
+		e.recordDropped()
+	case CloseSlow:
+		timeout := e.opts.SlowTimeout
+		if timeout <= 0 {
+			timeout = defaultSlowTimeout
+		}
+
+		now := time.Now()
+		e.mu.Lock()
+		if e.fullSince.IsZero() {
+			e.fullSince = now
+		}
+		expired := now.Sub(e.fullSince) > timeout
+		e.mu.Unlock()
+
+		if expired {
+			bus.Unsubscribe(topic, e.ch)
+		} else {
+			e.recordDropped()
+		}
+	}
+}
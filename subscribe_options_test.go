@@ -0,0 +1,105 @@
+// subscribe_options_test.go
+package eventbus_test
+
+import (
+	"testing"
+	"time"
+
+	eventbus "github.com/dozyio/go-event-bus"
+)
+
+func TestSubscribeWithOptionsDropOldest(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	ch, err := bus.SubscribeWithOptions("topic", eventbus.SubscribeOptions{
+		BufferSize:     2,
+		OverflowPolicy: eventbus.DropOldest,
+	})
+	if err != nil {
+		t.Fatalf("SubscribeWithOptions: %v", err)
+	}
+
+	bus.Publish("topic", 1)
+	bus.Publish("topic", 2)
+	bus.Publish("topic", 3) // should drop 1, keep [2, 3]
+
+	time.Sleep(50 * time.Millisecond)
+
+	v1, _ := receive(t, ch, 200*time.Millisecond)
+	v2, _ := receive(t, ch, 200*time.Millisecond)
+	if v1.(int) != 2 || v2.(int) != 3 {
+		t.Fatalf("got %v, %v; want 2, 3", v1, v2)
+	}
+
+	stats := bus.SubscriberStats("topic")
+	if len(stats) != 1 || stats[0].Dropped != 1 || stats[0].Delivered != 3 {
+		t.Fatalf("got stats %+v; want 1 dropped, 3 delivered", stats)
+	}
+}
+
+func TestSubscribeWithOptionsDropNewest(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	ch, err := bus.SubscribeWithOptions("topic", eventbus.SubscribeOptions{
+		BufferSize:     1,
+		OverflowPolicy: eventbus.DropNewest,
+	})
+	if err != nil {
+		t.Fatalf("SubscribeWithOptions: %v", err)
+	}
+
+	bus.Publish("topic", 1)
+	bus.Publish("topic", 2) // buffer full, dropped
+	time.Sleep(50 * time.Millisecond)
+
+	v, ok := receive(t, ch, 200*time.Millisecond)
+	if !ok || v.(int) != 1 {
+		t.Fatalf("got %v (open=%v); want 1", v, ok)
+	}
+
+	stats := bus.SubscriberStats("topic")
+	if len(stats) != 1 || stats[0].Dropped != 1 {
+		t.Fatalf("got stats %+v; want 1 dropped", stats)
+	}
+}
+
+func TestSubscribeWithOptionsSubscriberLimit(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	if _, err := bus.SubscribeWithOptions("topic", eventbus.SubscribeOptions{SubscriberLimit: 1}); err != nil {
+		t.Fatalf("first subscribe: %v", err)
+	}
+	if _, err := bus.SubscribeWithOptions("topic", eventbus.SubscribeOptions{}); err != eventbus.ErrTooManySubscribers {
+		t.Fatalf("got err %v; want ErrTooManySubscribers", err)
+	}
+}
+
+func TestSubscribeWithOptionsCloseSlow(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	ch, err := bus.SubscribeWithOptions("topic", eventbus.SubscribeOptions{
+		BufferSize:     1,
+		OverflowPolicy: eventbus.CloseSlow,
+		SlowTimeout:    10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("SubscribeWithOptions: %v", err)
+	}
+
+	bus.Publish("topic", 1) // fills the buffer
+	time.Sleep(5 * time.Millisecond)
+	bus.Publish("topic", 2) // still full, starts the slow timer
+	time.Sleep(20 * time.Millisecond)
+	bus.Publish("topic", 3) // now expired, should close the channel
+
+	time.Sleep(50 * time.Millisecond)
+	if c := bus.SubscriberCount("topic"); c != 0 {
+		t.Fatalf("got SubscriberCount %d; want 0 after CloseSlow", c)
+	}
+
+	// drain the buffered value, then expect the channel closed
+	<-ch
+	if _, ok := <-ch; ok {
+		t.Fatal("expected channel to be closed after CloseSlow timeout")
+	}
+}
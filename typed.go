@@ -0,0 +1,270 @@
+// typed.go
+package eventbus
+
+import (
+	"errors"
+	"log"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// ErrEmitterClosed is returned by Emitter/SubscribeType when the bus has
+// already been closed.
+var ErrEmitterClosed = ErrBusClosed
+
+// Bus is the reflect-based typed-event registration that backs the generic
+// Emitter and Subscription helpers. It is satisfied by *EventBus; tests may
+// substitute a mock implementation.
+type Bus interface {
+	// Emitter registers a new typed emitter for evtType.
+	Emitter(evtType reflect.Type) (*TypedEmitter, error)
+	// SubscribeType registers a new typed subscription for evtType.
+	SubscribeType(evtType reflect.Type) (*TypedSubscription, error)
+}
+
+// typedNode tracks, for a single reflect.Type, how many emitters are
+// currently registered and the live sink channels bound to that type. A
+// node is dropped from EventBus.typed once both counts reach zero, mirroring
+// the tryDropNode lifecycle of libp2p's eventbus.
+type typedNode struct {
+	nEmitters int
+	sinks     []reflect.Value // each is a chan T for the node's type
+}
+
+func (bus *EventBus) typedNodeLocked(t reflect.Type) *typedNode {
+	node, ok := bus.typed[t]
+	if !ok {
+		node = &typedNode{}
+		bus.typed[t] = node
+	}
+	return node
+}
+
+func (bus *EventBus) tryDropTypedNodeLocked(t reflect.Type, node *typedNode) {
+	if node.nEmitters == 0 && len(node.sinks) == 0 {
+		delete(bus.typed, t)
+	}
+}
+
+// Emitter registers a new emitter for evtType and returns a handle producers
+// use to emit values of that type. Callers normally use the generic
+// NewEmitter instead of calling this directly.
+func (bus *EventBus) Emitter(evtType reflect.Type) (*TypedEmitter, error) {
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+
+	if bus.closed {
+		return nil, ErrEmitterClosed
+	}
+	node := bus.typedNodeLocked(evtType)
+	node.nEmitters++
+	return &TypedEmitter{bus: bus, evtType: evtType}, nil
+}
+
+// SubscribeType registers a new subscription for evtType and returns a
+// handle wrapping a `chan T` (as a reflect.Value). Callers normally use the
+// generic NewSubscription instead of calling this directly.
+func (bus *EventBus) SubscribeType(evtType reflect.Type) (*TypedSubscription, error) {
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+
+	if bus.closed {
+		return nil, ErrEmitterClosed
+	}
+	node := bus.typedNodeLocked(evtType)
+	chVal := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, evtType), 0)
+	node.sinks = append(node.sinks, chVal)
+	return &TypedSubscription{bus: bus, evtType: evtType, ch: chVal}, nil
+}
+
+func (bus *EventBus) releaseEmitter(t reflect.Type) {
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+
+	node, ok := bus.typed[t]
+	if !ok {
+		return
+	}
+	node.nEmitters--
+	bus.tryDropTypedNodeLocked(t, node)
+}
+
+// dropTypedSink removes ch from t's sinks and reports whether it was still
+// registered; Close of the bus removes and closes every sink itself.
+func (bus *EventBus) dropTypedSink(t reflect.Type, ch reflect.Value) bool {
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+
+	node, ok := bus.typed[t]
+	if !ok {
+		return false
+	}
+	found := false
+	for i, sink := range node.sinks {
+		if sink.Pointer() == ch.Pointer() {
+			node.sinks = slices.Delete(node.sinks, i, i+1)
+			found = true
+			break
+		}
+	}
+	bus.tryDropTypedNodeLocked(t, node)
+	return found
+}
+
+// emitTyped delivers val to every sink registered for t, one goroutine per
+// sink, mirroring Publish's panic isolation and quitCh-guarded shutdown.
+func (bus *EventBus) emitTyped(t reflect.Type, val reflect.Value) {
+	bus.lock.RLock()
+	if bus.closed {
+		bus.lock.RUnlock()
+		return
+	}
+	node, ok := bus.typed[t]
+	if !ok {
+		bus.lock.RUnlock()
+		return
+	}
+	sinks := slices.Clone(node.sinks)
+	bus.lock.RUnlock()
+
+	quit := reflect.ValueOf(bus.quitCh)
+	for _, sink := range sinks {
+		bus.wg.Add(1)
+		go func(sink reflect.Value) {
+			defer bus.wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("eventbus: recovered from typed subscriber panic: %v", r)
+				}
+			}()
+			reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectSend, Chan: sink, Send: val},
+				{Dir: reflect.SelectRecv, Chan: quit},
+			})
+		}(sink)
+	}
+}
+
+// TypedEmitter is a reflect-typed handle producers use to emit events of a
+// single concrete Go type. Obtain one via EventBus.Emitter or, for a
+// type-safe wrapper, the generic NewEmitter.
+type TypedEmitter struct {
+	bus     *EventBus
+	evtType reflect.Type
+	mu      sync.Mutex
+	closed  bool
+}
+
+// Emit delivers evt to every subscription bound to the emitter's type. evt
+// must be assignable to that type.
+func (e *TypedEmitter) Emit(evt any) {
+	e.bus.emitTyped(e.evtType, reflect.ValueOf(evt))
+}
+
+// Close decrements the emitter's type's refcount, dropping the type's node
+// once no emitters or subscriptions remain. Close panics if called twice.
+func (e *TypedEmitter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		panic("eventbus: Emitter closed twice")
+	}
+	e.closed = true
+	e.bus.releaseEmitter(e.evtType)
+	return nil
+}
+
+// TypedSubscription is a reflect-typed handle wrapping a `chan T` bound to a
+// single concrete Go type. Obtain one via EventBus.SubscribeType or, for a
+// type-safe wrapper, the generic NewSubscription.
+type TypedSubscription struct {
+	bus     *EventBus
+	evtType reflect.Type
+	ch      reflect.Value
+	mu      sync.Mutex
+	closed  bool
+}
+
+// Chan returns the underlying `chan T`, as a reflect.Value.
+func (s *TypedSubscription) Chan() reflect.Value {
+	return s.ch
+}
+
+// Close removes the subscription's sink and closes its channel. It is safe
+// to call more than once; only the first call has any effect.
+func (s *TypedSubscription) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.bus.dropTypedSink(s.evtType, s.ch) {
+		s.ch.Close()
+	}
+	return nil
+}
+
+// Emitter is a type-safe handle producers use to emit values of T, backed by
+// a reflect-typed registration on a Bus.
+type Emitter[T any] struct {
+	inner *TypedEmitter
+}
+
+// NewEmitter registers a new Emitter for T on bus.
+func NewEmitter[T any](bus Bus) (*Emitter[T], error) {
+	var zero T
+	evtType := reflect.TypeOf(zero)
+	if evtType == nil {
+		return nil, errors.New("eventbus: cannot emit a nil interface type; use a concrete type for T")
+	}
+	te, err := bus.Emitter(evtType)
+	if err != nil {
+		return nil, err
+	}
+	return &Emitter[T]{inner: te}, nil
+}
+
+// Emit delivers evt to every Subscription[T] bound to the same bus.
+func (e *Emitter[T]) Emit(evt T) {
+	e.inner.Emit(evt)
+}
+
+// Close decrements the emitter's refcount. Close panics if called twice.
+func (e *Emitter[T]) Close() error {
+	return e.inner.Close()
+}
+
+// Subscription is a type-safe handle consumers use to receive values of T,
+// backed by a reflect-typed registration on a Bus.
+type Subscription[T any] struct {
+	inner *TypedSubscription
+}
+
+// NewSubscription registers a new Subscription for T on bus.
+func NewSubscription[T any](bus Bus) (*Subscription[T], error) {
+	var zero T
+	evtType := reflect.TypeOf(zero)
+	if evtType == nil {
+		return nil, errors.New("eventbus: cannot subscribe to a nil interface type; use a concrete type for T")
+	}
+	ts, err := bus.SubscribeType(evtType)
+	if err != nil {
+		return nil, err
+	}
+	return &Subscription[T]{inner: ts}, nil
+}
+
+// Out returns the channel values of T are delivered on.
+func (s *Subscription[T]) Out() <-chan T {
+	return s.inner.Chan().Interface().(chan T)
+}
+
+// Close removes the subscription and closes its channel. It is safe to call
+// more than once.
+func (s *Subscription[T]) Close() error {
+	return s.inner.Close()
+}
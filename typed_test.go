@@ -0,0 +1,84 @@
+// typed_test.go
+package eventbus_test
+
+import (
+	"testing"
+	"time"
+
+	eventbus "github.com/dozyio/go-event-bus"
+)
+
+type tradeEvent struct {
+	Symbol string
+	Price  float64
+}
+
+func TestTypedEmitSubscribe(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	sub, err := eventbus.NewSubscription[tradeEvent](bus)
+	if err != nil {
+		t.Fatalf("NewSubscription: %v", err)
+	}
+	defer sub.Close()
+
+	emitter, err := eventbus.NewEmitter[tradeEvent](bus)
+	if err != nil {
+		t.Fatalf("NewEmitter: %v", err)
+	}
+	defer emitter.Close()
+
+	emitter.Emit(tradeEvent{Symbol: "BTC", Price: 100})
+
+	select {
+	case evt, ok := <-sub.Out():
+		if !ok {
+			t.Fatal("expected subscription channel to be open")
+		}
+		if evt.Symbol != "BTC" || evt.Price != 100 {
+			t.Fatalf("got %+v; want {BTC 100}", evt)
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("timed out waiting for typed event")
+	}
+}
+
+func TestEmitterDoubleCloseSet(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	emitter, err := eventbus.NewEmitter[tradeEvent](bus)
+	if err != nil {
+		t.Fatalf("NewEmitter: %v", err)
+	}
+
+	if err := emitter.Close(); err != nil {
+		t.Fatalf("first Close: %v", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected second Close to panic")
+		}
+	}()
+	emitter.Close()
+}
+
+func TestSubscriptionCloseIsIdempotent(t *testing.T) {
+	bus := eventbus.NewEventBus()
+
+	sub, err := eventbus.NewSubscription[tradeEvent](bus)
+	if err != nil {
+		t.Fatalf("NewSubscription: %v", err)
+	}
+
+	if err := sub.Close(); err != nil {
+		t.Fatalf("first Close: %v", err)
+	}
+	if err := sub.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+
+	if _, ok := <-sub.Out(); ok {
+		t.Fatal("expected subscription channel to be closed")
+	}
+}